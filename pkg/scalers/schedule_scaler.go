@@ -2,6 +2,7 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -31,6 +32,25 @@ type scheduleMetadata struct {
 	timezone        string
 	desiredReplicas int64
 	scalerIndex     int
+
+	// useCronSchedule is true when start/end hold standard 5-field cron
+	// expressions instead of the bespoke natural-language interval/clock-time pair.
+	useCronSchedule bool
+
+	// weeklySchedule, when set, takes precedence over interval/start/end and
+	// useCronSchedule: it expresses a different time window per day of the week.
+	weeklySchedule *kedautil.Weekly
+
+	// startRRule/endRRule/rruleDuration hold an iCalendar RRULE-based schedule,
+	// taking precedence over everything above when set.
+	startRRule    string
+	endRRule      string
+	rruleDuration time.Duration
+
+	// ruleSet, when set, takes precedence over every other scheduling mode: a
+	// single trigger carries multiple day/time windows, each with its own
+	// desiredReplicas, plus date-specific exceptions.
+	ruleSet *kedautil.RuleSet
 }
 
 // NewScheduleScaler creates a new scheduleScaler
@@ -58,41 +78,114 @@ func parseScheduleMetadata(config *ScalerConfig) (*scheduleMetadata, error) {
 	}
 
 	meta := scheduleMetadata{}
-	if val, ok := config.TriggerMetadata["timezone"]; ok && val != "" {
-		meta.timezone = val
-	} else {
-		return nil, fmt.Errorf("no timezone specified. %s", config.TriggerMetadata)
+	resolvedTimezone, err := kedautil.ResolveTimezone(config.TriggerMetadata["timezone"])
+	if err != nil {
+		return nil, fmt.Errorf("error resolving timezone. %s: %w", config.TriggerMetadata, err)
 	}
-	if val, ok := config.TriggerMetadata["period"]; ok && val != "" {
+	meta.timezone = resolvedTimezone
+
+	// schedules: a list of {days, start, end, desiredReplicas} rules, optionally
+	// paired with exceptions overriding specific calendar dates. Takes precedence
+	// over every other scheduling mode below.
+	if val, ok := config.TriggerMetadata["schedules"]; ok && val != "" {
+		location, err := time.LoadLocation(meta.timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load timezone. Error: %w", err)
+		}
+		ruleSet, err := kedautil.NewRuleSet(location, val, config.TriggerMetadata["exceptions"])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing schedules metadata. %s: %w", config.TriggerMetadata, err)
+		}
+		meta.ruleSet = ruleSet
+		meta.scalerIndex = config.ScalerIndex
+		return &meta, nil
+	} else if val, ok := config.TriggerMetadata["startRRule"]; ok && val != "" {
+		meta.startRRule = val
+
+		endRRule, hasEndRRule := config.TriggerMetadata["endRRule"]
+		durationVal, hasDuration := config.TriggerMetadata["duration"]
+		switch {
+		case hasEndRRule && endRRule != "":
+			meta.endRRule = endRRule
+		case hasDuration && durationVal != "":
+			dur, err := time.ParseDuration(durationVal)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing duration metadata. %s: %w", config.TriggerMetadata, err)
+			}
+			meta.rruleDuration = dur
+		default:
+			return nil, fmt.Errorf("startRRule requires either endRRule or duration to be specified. %s", config.TriggerMetadata)
+		}
+
+		location, err := time.LoadLocation(meta.timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load timezone. Error: %w", err)
+		}
+		if _, _, _, err := kedautil.NextWindow(meta.startRRule, meta.endRRule, meta.rruleDuration, location, time.Now()); err != nil {
+			return nil, fmt.Errorf("error parsing RRULE schedule. %s: %w", config.TriggerMetadata, err)
+		}
+	} else if val, ok := config.TriggerMetadata["schedule"]; ok && val != "" {
+		var ranges map[string]string
+		if err := json.Unmarshal([]byte(val), &ranges); err != nil {
+			return nil, fmt.Errorf("error parsing schedule metadata. %s: %w", config.TriggerMetadata, err)
+		}
+		location, err := time.LoadLocation(meta.timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load timezone. Error: %w", err)
+		}
+		weekly, err := kedautil.NewWeekly(location, ranges)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing schedule metadata. %s: %w", config.TriggerMetadata, err)
+		}
+		meta.weeklySchedule = weekly
+	} else if val, ok := config.TriggerMetadata["period"]; ok && val == "cron" {
+		meta.useCronSchedule = true
+
+		start, ok := config.TriggerMetadata["start"]
+		if !ok || start == "" {
+			return nil, fmt.Errorf("no start cron expression specified. %s", config.TriggerMetadata)
+		}
+		end, ok := config.TriggerMetadata["end"]
+		if !ok || end == "" {
+			return nil, fmt.Errorf("no end cron expression specified. %s", config.TriggerMetadata)
+		}
+		if err := kedautil.ValidateCronWindow(start, end); err != nil {
+			return nil, fmt.Errorf("error parsing schedule. %s: %w", config.TriggerMetadata, err)
+		}
+		meta.start = start
+		meta.end = end
+	} else if ok && val != "" {
 		_, err := kedautil.ParseInterval(val)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing a period: %w", err)
 		}
 		meta.interval = val
-	} else {
-		return nil, fmt.Errorf("no start time specified. %s", config.TriggerMetadata)
-	}
-	if val, ok := config.TriggerMetadata["start"]; ok && val != "" {
-		_, err := kedautil.ParseTime(val)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing start time: %w", err)
+
+		if val, ok := config.TriggerMetadata["start"]; ok && val != "" {
+			_, err := kedautil.ParseTime(val)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing start time: %w", err)
+			}
+			meta.start = val
+		} else {
+			return nil, fmt.Errorf("no start time specified. %s", config.TriggerMetadata)
 		}
-		meta.start = val
-	} else {
-		return nil, fmt.Errorf("no start time specified. %s", config.TriggerMetadata)
-	}
-	if val, ok := config.TriggerMetadata["end"]; ok && val != "" {
-		_, err := kedautil.ParseTime(val)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing end time: %w", err)
+		if val, ok := config.TriggerMetadata["end"]; ok && val != "" {
+			_, err := kedautil.ParseTime(val)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing end time: %w", err)
+			}
+			meta.end = val
+		} else {
+			return nil, fmt.Errorf("no end time specified. %s", config.TriggerMetadata)
+		}
+		if meta.start == meta.end {
+			return nil, fmt.Errorf("error parsing schedule. %s: start and end can not have exactly same time input", config.TriggerMetadata)
 		}
-		meta.end = val
 	} else {
-		return nil, fmt.Errorf("no end time specified. %s", config.TriggerMetadata)
-	}
-	if meta.start == meta.end {
-		return nil, fmt.Errorf("error parsing schedule. %s: start and end can not have exactly same time input", config.TriggerMetadata)
+		return nil, fmt.Errorf("no start time specified. %s", config.TriggerMetadata)
 	}
+
 	if val, ok := config.TriggerMetadata["desiredReplicas"]; ok && val != "" {
 		metadataDesiredReplicas, err := strconv.Atoi(val)
 		if err != nil {
@@ -114,9 +207,18 @@ func (s *scheduleScaler) Close(context.Context) error {
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *scheduleScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	var specReplicas int64 = 1
+	metricDiscriminator := fmt.Sprintf("%s-%s-%s", s.metadata.interval, s.metadata.start, s.metadata.end)
+	switch {
+	case s.metadata.ruleSet != nil:
+		metricDiscriminator = "schedules"
+	case s.metadata.startRRule != "":
+		metricDiscriminator = kedautil.NormalizeString(fmt.Sprintf("rrule-%s-%s", s.metadata.startRRule, s.metadata.endRRule))
+	case s.metadata.weeklySchedule != nil:
+		metricDiscriminator = "weekly"
+	}
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("schedule-%s-%s-%s-%s", s.metadata.timezone, s.metadata.interval, s.metadata.start, s.metadata.end))),
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("schedule-%s-%s", s.metadata.timezone, metricDiscriminator))),
 		},
 		Target: GetMetricTarget(s.metricType, specReplicas),
 	}
@@ -133,8 +235,54 @@ func (s *scheduleScaler) GetMetricsAndActivity(_ context.Context, metricName str
 		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("unable to load timezone. Error: %w", err)
 	}
 
+	if s.metadata.ruleSet != nil {
+		desiredReplicas, active := s.metadata.ruleSet.Evaluate(time.Now())
+		if active {
+			metric := GenerateMetricInMili(metricName, float64(desiredReplicas))
+			return []external_metrics.ExternalMetricValue{metric}, true, nil
+		}
+		metric := GenerateMetricInMili(metricName, float64(defaultDesiredReplicas))
+		return []external_metrics.ExternalMetricValue{metric}, false, nil
+	}
+
+	if s.metadata.startRRule != "" {
+		_, _, active, err := kedautil.NextWindow(s.metadata.startRRule, s.metadata.endRRule, s.metadata.rruleDuration, location, time.Now())
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error evaluating RRULE schedule: %w", err)
+		}
+		if active {
+			metric := GenerateMetricInMili(metricName, float64(s.metadata.desiredReplicas))
+			return []external_metrics.ExternalMetricValue{metric}, true, nil
+		}
+		metric := GenerateMetricInMili(metricName, float64(defaultDesiredReplicas))
+		return []external_metrics.ExternalMetricValue{metric}, false, nil
+	}
+
+	if s.metadata.weeklySchedule != nil {
+		if s.metadata.weeklySchedule.Contains(time.Now()) {
+			metric := GenerateMetricInMili(metricName, float64(s.metadata.desiredReplicas))
+			return []external_metrics.ExternalMetricValue{metric}, true, nil
+		}
+		metric := GenerateMetricInMili(metricName, float64(defaultDesiredReplicas))
+		return []external_metrics.ExternalMetricValue{metric}, false, nil
+	}
+
+	if s.metadata.useCronSchedule {
+		active, _, _, err := kedautil.InCronWindow(s.metadata.start, s.metadata.end, location, time.Now())
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error evaluating cron schedule: %w", err)
+		}
+		if active {
+			metric := GenerateMetricInMili(metricName, float64(s.metadata.desiredReplicas))
+			return []external_metrics.ExternalMetricValue{metric}, true, nil
+		}
+		metric := GenerateMetricInMili(metricName, float64(defaultDesiredReplicas))
+		return []external_metrics.ExternalMetricValue{metric}, false, nil
+	}
+
 	// Since we are considering the timestamp here and not the exact time, timezone does matter.
-	currentTime := time.Now().Unix()
+	now := time.Now()
+	currentTime := now.Unix()
 	parsedInterval, err := kedautil.ParseInterval(s.metadata.interval)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error initializing interval: %v", parsedInterval)
@@ -148,12 +296,12 @@ func (s *scheduleScaler) GetMetricsAndActivity(_ context.Context, metricName str
 		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error initializing start time: %s", s.metadata.end)
 	}
 
-	nextStartTime, startTimescheduleErr := kedautil.ParseNextTime(parsedInterval, startTime, location)
+	nextStartTime, startTimescheduleErr := kedautil.ParseNextTime(parsedInterval, startTime, location, now)
 	if startTimescheduleErr != nil {
 		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error initializing start schedule: %w", startTimescheduleErr)
 	}
 
-	nextEndTime, endTimescheduleErr := kedautil.ParseNextTime(parsedInterval, endTime, location)
+	nextEndTime, endTimescheduleErr := kedautil.ParseNextTime(parsedInterval, endTime, location, now)
 	if endTimescheduleErr != nil {
 		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error intializing end schedule: %w", endTimescheduleErr)
 	}
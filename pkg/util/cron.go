@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronParser parses standard 5-field cron expressions (minute hour dom month dow).
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// ParseCronSchedule parses a standard 5-field cron expression into a cron.Schedule.
+func ParseCronSchedule(expr string) (cron.Schedule, error) {
+	schedule, err := cronParser.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse cron expression %q: %w", expr, err)
+	}
+	return schedule, nil
+}
+
+// maxPreviousCronLookback bounds how far back previousCronFire will search for a
+// firing of the schedule. cron.Schedule only exposes forward iteration via Next, so
+// there is no way to ask "when did this last fire" directly. Five years comfortably
+// covers every legitimate 5-field cron expression, including sparse ones like
+// "0 9 1 1,3,5,7,9,11 *" that only fire every other month, while still giving us a
+// bound to fail on instead of silently returning a time that was never an actual
+// firing.
+const maxPreviousCronLookback = 5 * 366 * 24 * time.Hour
+
+// initialCronLookback is the size of the first window previousCronFire searches.
+// Most schedules in practice fire at least once an hour, so this keeps the common
+// case cheap: a single short walk instead of one starting maxPreviousCronLookback
+// in the past.
+const initialCronLookback = time.Hour
+
+// previousCronFire returns the most recent fire time of schedule at or before now,
+// or an error if the schedule did not fire at all within maxPreviousCronLookback.
+//
+// It searches a window immediately before now and doubles the window on every miss
+// up to maxPreviousCronLookback, rather than always walking forward tick-by-tick
+// from now-maxPreviousCronLookback: the latter costs one schedule.Next call per
+// firing between the start of that fixed window and now, which scales with how
+// frequently the schedule fires (multiple seconds per call for a once-a-minute
+// expression) instead of with how long ago it last fired.
+func previousCronFire(schedule cron.Schedule, now time.Time) (time.Time, error) {
+	for lookback := initialCronLookback; lookback <= maxPreviousCronLookback; lookback *= 2 {
+		if last, ok := lastFireInWindow(schedule, now.Add(-lookback), now); ok {
+			return last, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cron schedule has no firing in the %s before %s; check the expression", maxPreviousCronLookback, now)
+}
+
+// lastFireInWindow walks schedule forward from start and returns the last firing at
+// or before end, if any.
+func lastFireInWindow(schedule cron.Schedule, start, end time.Time) (time.Time, bool) {
+	cursor := start
+	var last time.Time
+	for {
+		next := schedule.Next(cursor)
+		if next.After(end) {
+			break
+		}
+		last = next
+		cursor = next
+	}
+	return last, !last.IsZero()
+}
+
+// InCronWindow reports whether now falls inside the window opened by the most recent
+// firing of startExpr and closed by the next firing of endExpr after that start. It
+// returns the previous start and next end times so callers can surface them if needed.
+func InCronWindow(startExpr, endExpr string, loc *time.Location, now time.Time) (active bool, prevStart, nextEnd time.Time, err error) {
+	startSchedule, err := ParseCronSchedule(startExpr)
+	if err != nil {
+		return false, time.Time{}, time.Time{}, err
+	}
+	endSchedule, err := ParseCronSchedule(endExpr)
+	if err != nil {
+		return false, time.Time{}, time.Time{}, err
+	}
+
+	now = now.In(loc)
+	prevStart, err = previousCronFire(startSchedule, now)
+	if err != nil {
+		return false, time.Time{}, time.Time{}, err
+	}
+	nextEnd = endSchedule.Next(prevStart)
+
+	return !now.Before(prevStart) && now.Before(nextEnd), prevStart, nextEnd, nil
+}
+
+// cronOverlapSamples is how many consecutive start firings ValidateCronWindow checks
+// for overlap. InCronWindow only ever tracks a single previous start and its matching
+// end, so a handful of samples is enough to catch a pair that structurally overlaps
+// on every cycle without the check itself scaling with how far apart they are.
+const cronOverlapSamples = 3
+
+// ValidateCronWindow rejects malformed start/end cron expressions, the degenerate
+// case where both expressions are identical, and expressions that overlap: pairs
+// where the start expression fires again at or before the moment the end expression
+// closes the window the previous start opened. InCronWindow only tracks the most recent start
+// and the end that follows it, so an overlapping pair would make that tracking
+// ambiguous about which window is actually open.
+func ValidateCronWindow(startExpr, endExpr string) error {
+	if startExpr == endExpr {
+		return fmt.Errorf("error parsing schedule: start and end cron expressions can not be exactly the same: %s", startExpr)
+	}
+	startSchedule, err := ParseCronSchedule(startExpr)
+	if err != nil {
+		return fmt.Errorf("error parsing start cron expression: %w", err)
+	}
+	endSchedule, err := ParseCronSchedule(endExpr)
+	if err != nil {
+		return fmt.Errorf("error parsing end cron expression: %w", err)
+	}
+
+	cursor := time.Now()
+	for i := 0; i < cronOverlapSamples; i++ {
+		start := startSchedule.Next(cursor)
+		end := endSchedule.Next(start)
+		nextStart := startSchedule.Next(start)
+		if !nextStart.After(end) {
+			return fmt.Errorf("error parsing schedule: start cron expression %q fires again at %s before end cron expression %q closes the window opened at %s; the windows overlap", startExpr, nextStart, endExpr, start)
+		}
+		cursor = start
+	}
+	return nil
+}
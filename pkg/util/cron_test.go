@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInCronWindowLowFrequencyExpression(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+
+	// Fires at 09:00 on the 1st of every odd month, closing at 17:00 the same day:
+	// a legitimate but sparse (bimonthly) window, far older than previousCronFire's
+	// one-hour initial lookback, so the search window has to double several times.
+	startExpr := "0 9 1 1,3,5,7,9,11 *"
+	endExpr := "0 17 1 1,3,5,7,9,11 *"
+
+	// A few days after the July 1st firing, comfortably past its 17:00 close, with
+	// the next firing not until September 1st.
+	now := time.Date(2023, 7, 10, 12, 0, 0, 0, loc)
+
+	active, prevStart, nextEnd, err := InCronWindow(startExpr, endExpr, loc, now)
+	assert.NoError(t, err)
+	assert.False(t, active, "window closed on July 1st should not still report active on July 10th")
+	assert.Equal(t, time.Date(2023, 7, 1, 9, 0, 0, 0, loc), prevStart)
+	assert.Equal(t, time.Date(2023, 7, 1, 17, 0, 0, 0, loc), nextEnd)
+}
+
+func TestInCronWindowLowFrequencyExpressionActive(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	startExpr := "0 9 1 1,3,5,7,9,11 *"
+	endExpr := "0 17 1 1,3,5,7,9,11 *"
+
+	now := time.Date(2023, 7, 1, 12, 0, 0, 0, loc)
+
+	active, _, _, err := InCronWindow(startExpr, endExpr, loc, now)
+	assert.NoError(t, err)
+	assert.True(t, active)
+}
+
+// TestInCronWindowHighFrequencyExpressionIsFast guards against previousCronFire going
+// back to a tick-by-tick walk from now-maxPreviousCronLookback: for a once-a-minute
+// schedule, that walk would cost one schedule.Next call per minute of the five-year
+// lookback, millions of calls, and take seconds per call.
+func TestInCronWindowHighFrequencyExpressionIsFast(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	now := time.Date(2026, 7, 30, 12, 3, 0, 0, loc)
+
+	start := time.Now()
+	active, prevStart, nextEnd, err := InCronWindow("* * * * *", "*/5 * * * *", loc, now)
+	elapsed := time.Since(start)
+
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, time.Date(2026, 7, 30, 12, 3, 0, 0, loc), prevStart)
+	assert.Equal(t, time.Date(2026, 7, 30, 12, 5, 0, 0, loc), nextEnd)
+	assert.Less(t, elapsed, 100*time.Millisecond, "previousCronFire should not scale with how often the schedule fires across maxPreviousCronLookback")
+}
+
+func TestValidateCronWindowRejectsIdenticalExpressions(t *testing.T) {
+	err := ValidateCronWindow("0 9 * * *", "0 9 * * *")
+	assert.Error(t, err)
+}
+
+func TestValidateCronWindowRejectsOverlappingExpressions(t *testing.T) {
+	// Start fires every minute; end fires every 5 minutes. The next start (one
+	// minute later) always lands at or before the next multiple-of-5 end, so a
+	// window opened by one start is never closed before the next start opens
+	// another one.
+	err := ValidateCronWindow("* * * * *", "*/5 * * * *")
+	assert.Error(t, err)
+}
+
+func TestValidateCronWindowAcceptsNonOverlappingExpressions(t *testing.T) {
+	err := ValidateCronWindow("0 9 * * *", "0 17 * * *")
+	assert.NoError(t, err)
+}
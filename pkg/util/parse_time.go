@@ -89,7 +89,7 @@ func ParseInterval(interval string) (ParsedResult, error) {
 	}
 	if strings.Contains(interval, "week") {
 		if len(daysOfWeekMatches) > 0 {
-			return ParsedResult{Interval: "week", Targets: []string{strings.Join(daysOfWeekMatches, ",")}}, nil
+			return ParsedResult{Interval: "week", Targets: daysOfWeekMatches}, nil
 		}
 		return ParsedResult{Interval: "week", Targets: []string{"Monday"}}, nil
 	}
@@ -98,7 +98,7 @@ func ParseInterval(interval string) (ParsedResult, error) {
 	}
 	if strings.Contains(interval, "month") {
 		if len(daysOfMonthMatches) > 0 {
-			return ParsedResult{Interval: "month", Targets: []string{strings.Join(daysOfMonthMatches, ",")}}, nil
+			return ParsedResult{Interval: "month", Targets: daysOfMonthMatches}, nil
 		}
 		return ParsedResult{Interval: "month", Targets: []string{"1"}}, nil
 	}
@@ -106,73 +106,133 @@ func ParseInterval(interval string) (ParsedResult, error) {
 	return ParsedResult{}, fmt.Errorf("unable to parse period: %s", interval)
 }
 
-func getNextWeekday(weekdays []string) (time.Weekday, error) {
-	today := time.Now().Weekday()
-	for _, weekdayStr := range weekdays {
-		weekday, err := parseWeekday(weekdayStr)
-		if err != nil {
-			return 0, err
-		}
-		if weekday > today {
-			return weekday, nil
-		}
-	}
-	// If no next weekday is found in the array, return the first weekday in the array
-	firstWeekday, err := parseWeekday(weekdays[0])
-	if err != nil {
-		return 0, err
-	}
-	return firstWeekday, nil
-}
-
 func parseWeekday(weekdayStr string) (time.Weekday, error) {
 	switch strings.ToLower(weekdayStr) {
-	case "sunday":
+	case "sunday", "sun":
 		return time.Sunday, nil
-	case "monday":
+	case "monday", "mon":
 		return time.Monday, nil
-	case "tuesday":
+	case "tuesday", "tue":
 		return time.Tuesday, nil
-	case "wednesday":
+	case "wednesday", "wed":
 		return time.Wednesday, nil
-	case "thursday":
+	case "thursday", "thu":
 		return time.Thursday, nil
-	case "friday":
+	case "friday", "fri":
 		return time.Friday, nil
-	case "saturday":
+	case "saturday", "sat":
 		return time.Saturday, nil
 	default:
 		return 0, fmt.Errorf("invalid weekday: %s", weekdayStr)
 	}
 }
 
-func getNextDayOfMonth(daysStr []string) (int, error) {
-	days, _ := convertStrToIntSlice(daysStr)
-	today := time.Now().Day()
-	for _, day := range days {
-		if day > today {
-			return day, nil
+// ParseNextTime computes the true next occurrence of requiredTime under interval,
+// relative to now (so callers, and tests, control "the present" explicitly rather
+// than relying on time.Now() deep inside the helper).
+//
+// "day" rolls forward a day if requiredTime has already passed today. "week" picks
+// the smallest weekday strictly after today's weekday-and-time, wrapping to next
+// week when every target weekday has already had its turn this week. "month" picks
+// the smallest target day strictly after today's day-and-time in the current month,
+// skipping target days that don't exist in a given month (e.g. 31 in February)
+// rather than clamping them, and rolling forward to the next month that has a valid
+// occurrence if none remain this month.
+//
+// Only the time-of-day components of requiredTime are used; its date components
+// are ignored.
+func ParseNextTime(interval ParsedResult, requiredTime time.Time, location *time.Location, now time.Time) (time.Time, error) {
+	now = now.In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), requiredTime.Hour(), requiredTime.Minute(), requiredTime.Second(), requiredTime.Nanosecond(), location)
+
+	switch interval.Interval {
+	case "day":
+		if today.After(now) {
+			return today, nil
+		}
+		return today.AddDate(0, 0, 1), nil
+	case "week":
+		weekdays, err := parseWeekdays(interval.Targets)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return nextWeeklyOccurrence(weekdays, today, now), nil
+	case "month":
+		return nextMonthlyOccurrence(interval.Targets, requiredTime, location, now)
+	}
+	return time.Time{}, fmt.Errorf("invalid interval: %q", interval.Interval)
+}
+
+func parseWeekdays(targets []string) ([]time.Weekday, error) {
+	weekdays := make([]time.Weekday, 0, len(targets))
+	for _, target := range targets {
+		weekday, err := parseWeekday(target)
+		if err != nil {
+			return nil, err
 		}
+		weekdays = append(weekdays, weekday)
 	}
-	// If no next day is found in the array, return the first day in the array
-	return days[0], nil
+	return weekdays, nil
 }
 
-func parseNextTime(interval *ParsedResult, requiredTime *time.Time, location *time.Location) (time.Time, error) {
-	currentTime := time.Now()
-	if interval.Interval == "day" {
-		return time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), requiredTime.Hour(), requiredTime.Minute(), requiredTime.Second(), requiredTime.Nanosecond(), location), nil
+// nextWeeklyOccurrence returns the earliest candidate across all target weekdays,
+// using AddDate (rather than adding a fixed duration) so the result keeps the same
+// wall-clock time across any DST transition it crosses.
+func nextWeeklyOccurrence(weekdays []time.Weekday, today, now time.Time) time.Time {
+	bestOffset := -1
+	for _, weekday := range weekdays {
+		offset := int(weekday-now.Weekday()+7) % 7
+		if offset == 0 && !today.After(now) {
+			offset = 7
+		}
+		if bestOffset == -1 || offset < bestOffset {
+			bestOffset = offset
+		}
 	}
-	if interval.Interval == "week" {
-		getNextWeekday(interval.Targets)
-		return time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), requiredTime.Hour(), requiredTime.Minute(), requiredTime.Second(), requiredTime.Nanosecond(), location), nil
+	return today.AddDate(0, 0, bestOffset)
+}
+
+// nextMonthlyOccurrence returns the earliest candidate across all target days,
+// scanning forward month by month until it finds one where at least one target day
+// exists and is still ahead of now. For target days from 1 to 31 a valid occurrence
+// appears within a few months; the 24-month cap ends the search with an error when
+// no target day exists in any month (e.g. 0 or 32).
+func nextMonthlyOccurrence(targets []string, requiredTime time.Time, location *time.Location, now time.Time) (time.Time, error) {
+	days, err := convertStrToIntSlice(targets)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day of month: %w", err)
 	}
-	if interval.Interval == "month" {
-		nextDay, err := getNextDayOfMonth(interval.Targets)
-		if err != nil {
-			return time.Time{}, err
+
+	year, month := now.Year(), now.Month()
+	for offset := 0; offset < 24; offset++ {
+		y, m := addMonths(year, month, offset)
+		lastDay := daysInMonth(y, m)
+
+		var best time.Time
+		for _, day := range days {
+			if day < 1 || day > lastDay {
+				continue
+			}
+			candidate := time.Date(y, m, day, requiredTime.Hour(), requiredTime.Minute(), requiredTime.Second(), requiredTime.Nanosecond(), location)
+			if !candidate.After(now) {
+				continue
+			}
+			if best.IsZero() || candidate.Before(best) {
+				best = candidate
+			}
+		}
+		if !best.IsZero() {
+			return best, nil
 		}
-		return time.Date(currentTime.Year(), currentTime.Month(), nextDay, requiredTime.Hour(), requiredTime.Minute(), requiredTime.Second(), requiredTime.Nanosecond(), location), nil
 	}
-	return time.Time{}, fmt.Errorf("invalid data: %s", interval) // Make more convinient error
+	return time.Time{}, fmt.Errorf("no valid occurrence found for target days: %v", targets)
+}
+
+func addMonths(year int, month time.Month, offset int) (int, time.Month) {
+	total := int(month) - 1 + offset
+	return year + total/12, time.Month(total%12) + 1
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
 }
@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	assert.NoError(t, err)
+	return loc
+}
+
+func TestParseNextTimeDay(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	interval := ParsedResult{Interval: "day"}
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		requiredTime time.Time
+		expected     time.Time
+	}{
+		{
+			name:         "required time still ahead today",
+			now:          time.Date(2023, 5, 10, 8, 0, 0, 0, loc),
+			requiredTime: time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+			expected:     time.Date(2023, 5, 10, 9, 0, 0, 0, loc),
+		},
+		{
+			name:         "required time already passed today rolls to tomorrow",
+			now:          time.Date(2023, 5, 10, 10, 0, 0, 0, loc),
+			requiredTime: time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+			expected:     time.Date(2023, 5, 11, 9, 0, 0, 0, loc),
+		},
+		{
+			name:         "end of month rolls into next month",
+			now:          time.Date(2023, 4, 30, 10, 0, 0, 0, loc),
+			requiredTime: time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+			expected:     time.Date(2023, 5, 1, 9, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNextTime(interval, tt.requiredTime, loc, tt.now)
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "expected %s, got %s", tt.expected, got)
+		})
+	}
+}
+
+func TestParseNextTimeWeek(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	requiredTime := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		targets  []string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:    "same weekday, time not yet passed, fires today",
+			targets: []string{"Wednesday"},
+			// 2023-05-10 is a Wednesday.
+			now:      time.Date(2023, 5, 10, 8, 0, 0, 0, loc),
+			expected: time.Date(2023, 5, 10, 9, 0, 0, 0, loc),
+		},
+		{
+			name:    "same weekday, time already passed, wraps to next week",
+			targets: []string{"Wednesday"},
+			now:     time.Date(2023, 5, 10, 10, 0, 0, 0, loc),
+			// Next Wednesday.
+			expected: time.Date(2023, 5, 17, 9, 0, 0, 0, loc),
+		},
+		{
+			name:    "picks the smallest weekday strictly after today",
+			targets: []string{"Monday", "Friday"},
+			// 2023-05-10 is a Wednesday; next target is Friday.
+			now:      time.Date(2023, 5, 10, 12, 0, 0, 0, loc),
+			expected: time.Date(2023, 5, 12, 9, 0, 0, 0, loc),
+		},
+		{
+			name:    "wraps across a year boundary",
+			targets: []string{"Monday"},
+			// 2023-12-31 is a Sunday.
+			now:      time.Date(2023, 12, 31, 23, 0, 0, 0, loc),
+			expected: time.Date(2024, 1, 1, 9, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval := ParsedResult{Interval: "week", Targets: tt.targets}
+			got, err := ParseNextTime(interval, requiredTime, loc, tt.now)
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "expected %s, got %s", tt.expected, got)
+		})
+	}
+}
+
+func TestParseNextTimeWeekDSTTransition(t *testing.T) {
+	// America/New_York springs forward on 2023-03-12 at 02:00 local time.
+	loc := mustLoadLocation(t, "America/New_York")
+	requiredTime := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC)
+	interval := ParsedResult{Interval: "week", Targets: []string{"Sunday"}}
+
+	// 2023-03-05 is a Sunday, already past its 9am fire time; the next Sunday,
+	// 2023-03-12, is the day DST begins. AddDate must still land on 09:00 local
+	// wall-clock time rather than a fixed 7*24h later.
+	now := time.Date(2023, 3, 5, 10, 0, 0, 0, loc)
+	expected := time.Date(2023, 3, 12, 9, 0, 0, 0, loc)
+
+	got, err := ParseNextTime(interval, requiredTime, loc, now)
+	assert.NoError(t, err)
+	assert.True(t, expected.Equal(got), "expected %s, got %s", expected, got)
+	assert.Equal(t, 9, got.Hour())
+}
+
+func TestParseNextTimeMonth(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	requiredTime := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		targets  []string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "target day later this month",
+			targets:  []string{"15"},
+			now:      time.Date(2023, 2, 1, 0, 0, 0, 0, loc),
+			expected: time.Date(2023, 2, 15, 9, 0, 0, 0, loc),
+		},
+		{
+			name:    "day 31 skipped in February, rolls to March",
+			targets: []string{"31"},
+			now:     time.Date(2023, 2, 1, 0, 0, 0, 0, loc),
+			// February 2023 has no 31st, so the next valid occurrence is in March.
+			expected: time.Date(2023, 3, 31, 9, 0, 0, 0, loc),
+		},
+		{
+			name:    "day 31 skipped across a 30-day month too",
+			targets: []string{"31"},
+			now:     time.Date(2023, 3, 31, 10, 0, 0, 0, loc),
+			// March 31 has passed; April has no 31st either, so May 31 is next.
+			expected: time.Date(2023, 5, 31, 9, 0, 0, 0, loc),
+		},
+		{
+			name:     "picks the smallest valid target day after today",
+			targets:  []string{"5", "20"},
+			now:      time.Date(2023, 6, 10, 0, 0, 0, 0, loc),
+			expected: time.Date(2023, 6, 20, 9, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval := ParsedResult{Interval: "month", Targets: tt.targets}
+			got, err := ParseNextTime(interval, requiredTime, loc, tt.now)
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "expected %s, got %s", tt.expected, got)
+		})
+	}
+}
+
+func TestParseIntervalSplitsTargets(t *testing.T) {
+	result, err := ParseInterval("every week on Monday, Wednesday and Friday")
+	assert.NoError(t, err)
+	assert.Equal(t, "week", result.Interval)
+	assert.Equal(t, []string{"Monday", "Wednesday", "Friday"}, result.Targets)
+
+	result, err = ParseInterval("every month on the 1st and 15th")
+	assert.NoError(t, err)
+	assert.Equal(t, "month", result.Interval)
+	assert.Equal(t, []string{"1", "15"}, result.Targets)
+}
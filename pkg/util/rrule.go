@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// rruleEpoch anchors every RRULE we evaluate. INTERVAL>1 rules (e.g.
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO") derive which occurrences count from the
+// DTSTART they're anchored to, so the anchor must be a fixed point in time rather
+// than something derived from the `now` passed into NextWindow — otherwise the
+// same wall-clock Monday would classify as an "on" or "off" week depending on
+// when the caller happened to evaluate it.
+var rruleEpoch = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// NextWindow finds the most recent occurrence of startRule at or before now and the
+// end of the window it opens, either the next occurrence of endRule after that start
+// or start+dur when endRule is empty. It reports whether now falls inside that window.
+//
+// Exactly one of endRule or dur must be provided; passing both or neither is an error.
+func NextWindow(startRule, endRule string, dur time.Duration, loc *time.Location, now time.Time) (start, end time.Time, active bool, err error) {
+	if (endRule == "") == (dur == 0) {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("exactly one of endRRule or duration must be set")
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	now = now.In(loc)
+
+	startRRule, err := newRRule(startRule, loc, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("unable to parse startRRule %q: %w", startRule, err)
+	}
+	start = startRRule.Before(now, true)
+	if start.IsZero() {
+		// No occurrence of startRule has happened yet; the window cannot be active.
+		return time.Time{}, time.Time{}, false, nil
+	}
+
+	if dur != 0 {
+		end = start.Add(dur)
+	} else {
+		endRRule, err := newRRule(endRule, loc, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, false, fmt.Errorf("unable to parse endRRule %q: %w", endRule, err)
+		}
+		end = endRRule.After(start, false)
+		if end.IsZero() {
+			return time.Time{}, time.Time{}, false, fmt.Errorf("endRRule %q never fires after start %s", endRule, start)
+		}
+	}
+
+	active = !now.Before(start) && now.Before(end)
+	return start, end, active, nil
+}
+
+// newRRule parses an RFC 5545 recurrence rule (without a DTSTART line) and anchors it
+// at a point derived from rruleEpoch, converted to loc, so its phase is stable across
+// calls. Before/After walk the rule's occurrences one by one starting at its Dtstart,
+// so anchoring directly at rruleEpoch gets more expensive every year: evaluating a
+// once-a-minute rule in 2026 means iterating millions of occurrences since 1970. We
+// instead rebase Dtstart to the occurrence of rruleEpoch's own grid that is one whole
+// period before now, via rebaseDtstart - close enough to now that Before/After only
+// ever walk a handful of occurrences, but still congruent with the original anchor so
+// INTERVAL>1 rules classify the same wall-clock time the same way regardless of now.
+func newRRule(rule string, loc *time.Location, now time.Time) (*rrule.RRule, error) {
+	option, err := rrule.StrToROption(rule)
+	if err != nil {
+		return nil, err
+	}
+	option.Dtstart = rebaseDtstart(rruleEpoch.In(loc), now.In(loc), option.Freq, option.Interval)
+	return rrule.NewRRule(*option)
+}
+
+// rebaseDtstart returns the latest point on epoch's FREQ/INTERVAL grid that is at
+// least one full period before now, or epoch itself if now hasn't reached epoch plus
+// one period yet. Stepping back in whole periods keeps "periods elapsed since epoch,
+// modulo interval" unchanged, which is all an INTERVAL>1 rule's phase depends on.
+func rebaseDtstart(epoch, now time.Time, freq rrule.Frequency, interval int) time.Time {
+	if interval <= 0 {
+		interval = 1
+	}
+	if !now.After(epoch) {
+		return epoch
+	}
+
+	if freq == rrule.MONTHLY || freq == rrule.YEARLY {
+		unitMonths := interval
+		if freq == rrule.YEARLY {
+			unitMonths = interval * 12
+		}
+		elapsedMonths := monthsElapsed(epoch, now)
+		periods := elapsedMonths/unitMonths - 1
+		if periods <= 0 {
+			return epoch
+		}
+		return epoch.AddDate(0, periods*unitMonths, 0)
+	}
+
+	var unit time.Duration
+	switch freq {
+	case rrule.SECONDLY:
+		unit = time.Second
+	case rrule.MINUTELY:
+		unit = time.Minute
+	case rrule.HOURLY:
+		unit = time.Hour
+	case rrule.WEEKLY:
+		unit = 7 * 24 * time.Hour
+	default: // rrule.DAILY
+		unit = 24 * time.Hour
+	}
+	period := time.Duration(interval) * unit
+	periods := now.Sub(epoch)/period - 1
+	if periods <= 0 {
+		return epoch
+	}
+	return epoch.Add(periods * period)
+}
+
+// monthsElapsed returns the number of whole calendar months between a and b, where b
+// is after a.
+func monthsElapsed(a, b time.Time) int {
+	months := (b.Year()-a.Year())*12 + int(b.Month()) - int(a.Month())
+	if b.Day() < a.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNextWindowBiweeklyPhaseIsStable pins down that an INTERVAL=2 rule's on/off
+// phase for a given wall-clock Monday does not depend on what `now` happened to be
+// when a caller last evaluated it — only on the fixed RRULE anchor.
+func TestNextWindowBiweeklyPhaseIsStable(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	startRule := "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO;BYHOUR=9;BYMINUTE=0;BYSECOND=0"
+	endRule := "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO;BYHOUR=17;BYMINUTE=0;BYSECOND=0"
+
+	// Two consecutive Mondays, one week apart. Under a biweekly rule, exactly one
+	// of them is an "on" week; which one is determined solely by the rule's
+	// anchor, not by which Monday we ask about first.
+	mondayA := time.Date(2023, 8, 7, 10, 0, 0, 0, loc)
+	mondayB := time.Date(2023, 8, 14, 10, 0, 0, 0, loc)
+
+	_, _, activeA, err := NextWindow(startRule, endRule, 0, loc, mondayA)
+	assert.NoError(t, err)
+
+	_, _, activeB, err := NextWindow(startRule, endRule, 0, loc, mondayB)
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, activeA, activeB, "consecutive Mondays under an INTERVAL=2 rule must alternate on/off")
+
+	// Evaluating mondayA's window a second time, after having already evaluated a
+	// later `now`, must return the same result: the anchor must not drift with
+	// the call order.
+	_, _, activeARepeat, err := NextWindow(startRule, endRule, 0, loc, mondayA)
+	assert.NoError(t, err)
+	assert.Equal(t, activeA, activeARepeat, "the same wall-clock Monday must classify the same way regardless of call order")
+}
+
+func TestNextWindowWithDuration(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	startRule := "FREQ=DAILY;BYHOUR=9;BYMINUTE=0;BYSECOND=0"
+
+	now := time.Date(2023, 8, 7, 10, 0, 0, 0, loc)
+	start, end, active, err := NextWindow(startRule, "", 2*time.Hour, loc, now)
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, time.Date(2023, 8, 7, 9, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2023, 8, 7, 11, 0, 0, 0, loc), end)
+}
+
+// TestNextWindowHighFrequencyRuleIsFast guards against newRRule anchoring every rule
+// at the fixed rruleEpoch: RRule.Before/After walk occurrences one by one starting at
+// Dtstart, so a once-a-minute rule anchored at 1970 gets more expensive every year
+// that passes. Evaluated decades later, that walk would take seconds per call.
+func TestNextWindowHighFrequencyRuleIsFast(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	startRule := "FREQ=MINUTELY;BYHOUR=9,10,11,12,13,14,15,16"
+	now := time.Date(2026, 7, 30, 12, 3, 0, 0, loc)
+
+	start := time.Now()
+	_, _, active, err := NextWindow(startRule, "", time.Minute, loc, now)
+	elapsed := time.Since(start)
+
+	assert.NoError(t, err)
+	assert.True(t, active)
+	assert.Less(t, elapsed, 100*time.Millisecond, "newRRule should not scale with how many years have passed since the epoch")
+}
+
+// TestRebaseDtstartPreservesMonthlyPhase pins down that rebasing a MONTHLY/YEARLY
+// rule's Dtstart forward in whole INTERVAL-months keeps its on/off phase the same as
+// the original fixed epoch would have produced.
+func TestRebaseDtstartPreservesMonthlyPhase(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	startRule := "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1;BYHOUR=9;BYMINUTE=0;BYSECOND=0"
+	endRule := "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1;BYHOUR=17;BYMINUTE=0;BYSECOND=0"
+
+	// rruleEpoch is 1970-01-01, a quarter boundary under INTERVAL=3, so quarter
+	// starts (Jan, Apr, Jul, Oct) are "on" months far in the future too. A one-month
+	// shift bug in the rebase math would misclassify June, August, or October.
+	assertActive := func(now time.Time, want bool) {
+		t.Helper()
+		_, _, active, err := NextWindow(startRule, endRule, 0, loc, now)
+		assert.NoError(t, err)
+		assert.Equal(t, want, active)
+	}
+
+	assertActive(time.Date(2026, 6, 1, 12, 0, 0, 0, loc), false)
+	assertActive(time.Date(2026, 7, 1, 12, 0, 0, 0, loc), true)
+	assertActive(time.Date(2026, 8, 1, 12, 0, 0, 0, loc), false)
+	assertActive(time.Date(2026, 10, 1, 12, 0, 0, 0, loc), true)
+}
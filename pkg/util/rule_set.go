@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// exceptionDateLayout is the wire format for ScheduleException.Date.
+const exceptionDateLayout = "2006-01-02"
+
+// ScheduleRule is one entry of a multi-window schedule: a desiredReplicas value
+// that applies during the time window described by Days/Start/End.
+type ScheduleRule struct {
+	Days            []string `json:"days"`
+	Start           string   `json:"start"`
+	End             string   `json:"end"`
+	DesiredReplicas int64    `json:"desiredReplicas"`
+
+	weekly *Weekly
+}
+
+// ScheduleException overrides every rule on a single calendar date. An exception
+// with no Start/End applies its DesiredReplicas to that whole date (e.g. a holiday
+// with 0); one with both set instead replaces the day's windows with this single
+// range.
+type ScheduleException struct {
+	Date            string `json:"date"`
+	Start           string `json:"start,omitempty"`
+	End             string `json:"end,omitempty"`
+	DesiredReplicas int64  `json:"desiredReplicas"`
+}
+
+// RuleSet is a parsed, ready-to-evaluate collection of ScheduleRules and
+// ScheduleExceptions sharing a single timezone.
+type RuleSet struct {
+	location   *time.Location
+	rules      []ScheduleRule
+	exceptions map[string]ScheduleException
+}
+
+// NewRuleSet parses schedulesJSON (a JSON array of ScheduleRule, required) and
+// exceptionsJSON (a JSON array of ScheduleException, may be empty) into a RuleSet
+// evaluated in loc.
+func NewRuleSet(loc *time.Location, schedulesJSON, exceptionsJSON string) (*RuleSet, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var rules []ScheduleRule
+	if err := json.Unmarshal([]byte(schedulesJSON), &rules); err != nil {
+		return nil, fmt.Errorf("unable to parse schedules: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("schedules must contain at least one rule")
+	}
+	for i := range rules {
+		ranges := make(map[string]string, len(rules[i].Days))
+		for _, day := range rules[i].Days {
+			ranges[day] = fmt.Sprintf("%s-%s", rules[i].Start, rules[i].End)
+		}
+		weekly, err := NewWeekly(loc, ranges)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule for days %v: %w", rules[i].Days, err)
+		}
+		rules[i].weekly = weekly
+	}
+
+	exceptions := map[string]ScheduleException{}
+	if exceptionsJSON != "" {
+		var list []ScheduleException
+		if err := json.Unmarshal([]byte(exceptionsJSON), &list); err != nil {
+			return nil, fmt.Errorf("unable to parse exceptions: %w", err)
+		}
+		for _, exception := range list {
+			if _, err := time.ParseInLocation(exceptionDateLayout, exception.Date, loc); err != nil {
+				return nil, fmt.Errorf("invalid exception date %q: %w", exception.Date, err)
+			}
+			exceptions[exception.Date] = exception
+		}
+	}
+
+	return &RuleSet{location: loc, rules: rules, exceptions: exceptions}, nil
+}
+
+// Evaluate returns the maximum desiredReplicas among the rules matching now and
+// whether anything matched at all. A date-specific exception, when present, fully
+// replaces that day's rules rather than being merged with them.
+func (rs *RuleSet) Evaluate(now time.Time) (desiredReplicas int64, active bool) {
+	now = now.In(rs.location)
+
+	if exception, ok := rs.exceptions[now.Format(exceptionDateLayout)]; ok {
+		if exception.Start == "" && exception.End == "" {
+			// No start/end narrows the exception to a sub-range of the day, so it
+			// applies to the whole day instead: the exception's desiredReplicas is
+			// what should be active for every minute of it, not "nothing is active"
+			// (which would let the normal rules' default take over instead).
+			return exception.DesiredReplicas, true
+		}
+		weekday := strings.ToLower(now.Weekday().String())
+		weekly, err := NewWeekly(rs.location, map[string]string{weekday: fmt.Sprintf("%s-%s", exception.Start, exception.End)})
+		if err != nil || !weekly.Contains(now) {
+			return 0, false
+		}
+		return exception.DesiredReplicas, true
+	}
+
+	var maxReplicas int64
+	matched := false
+	for _, rule := range rs.rules {
+		if !rule.weekly.Contains(now) {
+			continue
+		}
+		if !matched || rule.DesiredReplicas > maxReplicas {
+			maxReplicas = rule.DesiredReplicas
+		}
+		matched = true
+	}
+	return maxReplicas, matched
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSetEvaluateOvernightRule(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	schedules := `[{"days": ["Fri"], "start": "22:00", "end": "06:00", "desiredReplicas": 5}]`
+
+	ruleSet, err := NewRuleSet(loc, schedules, "")
+	assert.NoError(t, err)
+
+	// Friday 23:00: the late half of the overnight window, on the day it starts.
+	replicas, active := ruleSet.Evaluate(time.Date(2023, 5, 12, 23, 0, 0, 0, loc))
+	assert.True(t, active)
+	assert.Equal(t, int64(5), replicas)
+
+	// Saturday 02:00: the early half, spilling over from Friday.
+	replicas, active = ruleSet.Evaluate(time.Date(2023, 5, 13, 2, 0, 0, 0, loc))
+	assert.True(t, active)
+	assert.Equal(t, int64(5), replicas)
+
+	// Saturday 07:00: past the window entirely.
+	_, active = ruleSet.Evaluate(time.Date(2023, 5, 13, 7, 0, 0, 0, loc))
+	assert.False(t, active)
+}
+
+func TestRuleSetEvaluateExceptions(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	schedules := `[{"days": ["Mon","Tue","Wed","Thu","Fri"], "start": "09:00", "end": "17:00", "desiredReplicas": 10}]`
+	exceptions := `[
+		{"date": "2025-12-25", "desiredReplicas": 0},
+		{"date": "2025-11-28", "start": "12:00", "end": "16:00", "desiredReplicas": 5}
+	]`
+
+	ruleSet, err := NewRuleSet(loc, schedules, exceptions)
+	assert.NoError(t, err)
+
+	// 2025-12-25 is a Thursday that would otherwise match the weekday rule, but the
+	// holiday exception replaces it with its own desiredReplicas for the whole day
+	// rather than falling back to "inactive".
+	replicas, active := ruleSet.Evaluate(time.Date(2025, 12, 25, 12, 0, 0, 0, loc))
+	assert.True(t, active)
+	assert.Equal(t, int64(0), replicas)
+
+	// 2025-11-28 replaces the day's window with a narrower 12:00-16:00 range.
+	replicas, active = ruleSet.Evaluate(time.Date(2025, 11, 28, 13, 0, 0, 0, loc))
+	assert.True(t, active)
+	assert.Equal(t, int64(5), replicas)
+
+	// Outside the exception's narrower range, even though it's within the normal
+	// rule's 09:00-17:00 window.
+	_, active = ruleSet.Evaluate(time.Date(2025, 11, 28, 10, 0, 0, 0, loc))
+	assert.False(t, active)
+
+	// An unaffected weekday still follows the regular rule.
+	replicas, active = ruleSet.Evaluate(time.Date(2025, 12, 1, 10, 0, 0, 0, loc))
+	assert.True(t, active)
+	assert.Equal(t, int64(10), replicas)
+}
+
+func TestRuleSetEvaluateMaximumAcrossOverlappingRules(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+	schedules := `[
+		{"days": ["Mon"], "start": "09:00", "end": "17:00", "desiredReplicas": 10},
+		{"days": ["Mon"], "start": "12:00", "end": "14:00", "desiredReplicas": 20}
+	]`
+
+	ruleSet, err := NewRuleSet(loc, schedules, "")
+	assert.NoError(t, err)
+
+	replicas, active := ruleSet.Evaluate(time.Date(2023, 5, 8, 13, 0, 0, 0, loc)) // a Monday
+	assert.True(t, active)
+	assert.Equal(t, int64(20), replicas)
+}
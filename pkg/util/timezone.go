@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// zoneinfoDir is where /etc/localtime is expected to symlink into.
+const zoneinfoDir = "/usr/share/zoneinfo/"
+
+// deprecatedTimezoneAliases maps legacy IANA zone names that tzdata still
+// resolves, via a Link, to their canonical replacement. Accepting them silently
+// risks behavior drifting out from under an operator when a future tzdata
+// release changes how the alias resolves, so we reject them with guidance
+// instead.
+var deprecatedTimezoneAliases = map[string]string{
+	"Europe/Kiev":   "Europe/Kyiv",
+	"US/Eastern":    "America/New_York",
+	"US/Central":    "America/Chicago",
+	"US/Mountain":   "America/Denver",
+	"US/Pacific":    "America/Los_Angeles",
+	"Asia/Calcutta": "Asia/Kolkata",
+	"Asia/Saigon":   "Asia/Ho_Chi_Minh",
+}
+
+// ResolveTimezone validates tz against the IANA database and rejects known
+// deprecated aliases with an actionable error. An empty tz, or the sentinel
+// values "Local"/"auto", resolves to the host's detected IANA zone instead of
+// failing, so GetMetricSpecForScaling can still derive a stable metric name.
+func ResolveTimezone(tz string) (string, error) {
+	if tz == "" || tz == "Local" || tz == "auto" {
+		return detectHostTimezone(), nil
+	}
+	if canonical, deprecated := deprecatedTimezoneAliases[tz]; deprecated {
+		return "", fmt.Errorf("timezone %q is a deprecated IANA alias, use %q instead", tz, canonical)
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return "", fmt.Errorf("invalid timezone %q: %w", tz, err)
+	}
+	return tz, nil
+}
+
+// detectHostTimezone resolves the IANA zone name of the host the operator pod is
+// running on, trying in order: /etc/timezone, the /etc/localtime symlink
+// target, the TZ environment variable, and finally UTC.
+func detectHostTimezone() string {
+	if tz, ok := readEtcTimezone(); ok {
+		return tz
+	}
+	if tz, ok := resolveLocaltimeSymlink(); ok {
+		return tz
+	}
+	if tz := os.Getenv("TZ"); tz != "" {
+		if _, err := time.LoadLocation(tz); err == nil {
+			return tz
+		}
+	}
+	return "UTC"
+}
+
+func readEtcTimezone() (string, bool) {
+	data, err := os.ReadFile("/etc/timezone")
+	if err != nil {
+		return "", false
+	}
+	tz := strings.TrimSpace(string(data))
+	if _, err := time.LoadLocation(tz); err != nil {
+		return "", false
+	}
+	return tz, true
+}
+
+func resolveLocaltimeSymlink() (string, bool) {
+	target, err := os.Readlink("/etc/localtime")
+	if err != nil {
+		return "", false
+	}
+	idx := strings.Index(target, zoneinfoDir)
+	if idx == -1 {
+		return "", false
+	}
+	tz := target[idx+len(zoneinfoDir):]
+	if _, err := time.LoadLocation(tz); err != nil {
+		return "", false
+	}
+	return tz, true
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+// dayRange is a window of minutes since midnight local time for a single day of
+// the week. end == 0 means there is no window that day. end < start means the
+// window wraps past midnight into the following day.
+type dayRange struct {
+	startMinutes uint16
+	endMinutes   uint16
+}
+
+// Weekly is a per-day-of-week time window, e.g. "09:00-17:00 on weekdays,
+// 10:00-14:00 on Saturday, closed on Sunday". It is modelled as a fixed array
+// indexed by time.Weekday rather than a map so lookups are O(1) and zero-value
+// safe (an unset day simply never matches).
+type Weekly struct {
+	location *time.Location
+	days     [7]dayRange
+}
+
+// NewWeekly builds a Weekly schedule for loc from a map of weekday name (as
+// accepted by parseWeekday, case-insensitive) to a "HH:MM-HH:MM" range. A
+// range of "-" or "" means the day has no window. Omitted days default to no
+// window as well.
+func NewWeekly(loc *time.Location, ranges map[string]string) (*Weekly, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	w := &Weekly{location: loc}
+	for name, rangeStr := range ranges {
+		weekday, err := parseWeekday(name)
+		if err != nil {
+			return nil, err
+		}
+		dr, err := parseDayRange(rangeStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range for %s: %w", name, err)
+		}
+		w.days[weekday] = dr
+	}
+	return w, nil
+}
+
+func parseDayRange(rangeStr string) (dayRange, error) {
+	rangeStr = strings.TrimSpace(rangeStr)
+	if rangeStr == "" || rangeStr == "-" {
+		return dayRange{}, nil
+	}
+
+	parts := strings.SplitN(rangeStr, "-", 2)
+	if len(parts) != 2 {
+		return dayRange{}, fmt.Errorf("expected format \"HH:MM-HH:MM\", got %q", rangeStr)
+	}
+	start, err := parseMinutesOfDay(parts[0])
+	if err != nil {
+		return dayRange{}, err
+	}
+	end, err := parseMinutesOfDay(parts[1])
+	if err != nil {
+		return dayRange{}, err
+	}
+	if end == 0 {
+		// Midnight as an end time would be indistinguishable from "no window", so
+		// an end of 00:00 is stored as minutesPerDay, the same as 24:00.
+		end = minutesPerDay
+	}
+	return dayRange{startMinutes: start, endMinutes: end}, nil
+}
+
+func parseMinutesOfDay(s string) (uint16, error) {
+	s = strings.TrimSpace(s)
+	hm := strings.SplitN(s, ":", 2)
+	if len(hm) != 2 {
+		return 0, fmt.Errorf("expected HH:MM, got %q", s)
+	}
+	hour, err := strconv.Atoi(hm[0])
+	if err != nil || hour < 0 || hour > 24 {
+		return 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	minute, err := strconv.Atoi(hm[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	total := hour*60 + minute
+	if total > minutesPerDay {
+		return 0, fmt.Errorf("time out of range in %q", s)
+	}
+	return uint16(total), nil
+}
+
+// Contains reports whether t falls inside the schedule's window, after
+// converting t to the schedule's location.
+func (w *Weekly) Contains(t time.Time) bool {
+	t = t.In(w.location)
+	minutesToday := uint16(t.Hour()*60 + t.Minute())
+
+	today := w.days[t.Weekday()]
+	if today.endMinutes != 0 {
+		if today.endMinutes > today.startMinutes {
+			if minutesToday >= today.startMinutes && minutesToday < today.endMinutes {
+				return true
+			}
+		} else if minutesToday >= today.startMinutes {
+			// end <= start means today's window wraps past midnight; minutesToday
+			// being at or after start puts us in the late half of that window.
+			return true
+		}
+	}
+
+	// A window that wraps past midnight is recorded on the day it starts, so we
+	// must also check whether yesterday's window spills into the early part of
+	// today.
+	yesterday := w.days[prevWeekday(t.Weekday())]
+	if yesterday.endMinutes != 0 && yesterday.endMinutes <= yesterday.startMinutes && minutesToday < yesterday.endMinutes {
+		return true
+	}
+	return false
+}
+
+// NextTransition returns the next time, strictly after t, at which Contains
+// would flip value (entering or leaving the window).
+func (w *Weekly) NextTransition(t time.Time) time.Time {
+	t = t.In(w.location)
+	currentlyIn := w.Contains(t)
+
+	// Scan forward minute by minute across up to 8 days (a full week plus a day
+	// of margin for wrap-around), which is cheap since schedules are evaluated
+	// far less often than once a minute.
+	cursor := t.Add(time.Minute).Truncate(time.Minute)
+	limit := t.AddDate(0, 0, 8)
+	for cursor.Before(limit) {
+		if w.Contains(cursor) != currentlyIn {
+			return cursor
+		}
+		cursor = cursor.Add(time.Minute)
+	}
+	return limit
+}
+
+func prevWeekday(d time.Weekday) time.Weekday {
+	return (d + 6) % 7
+}
+
+// weeklyYAML is the wire format for Weekly: a map of weekday name to a
+// "HH:MM-HH:MM" range string.
+type weeklyYAML map[string]string
+
+// MarshalJSON implements json.Marshaler.
+func (w *Weekly) MarshalJSON() ([]byte, error) {
+	out := weeklyYAML{}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		dr := w.days[weekday]
+		if dr.endMinutes == 0 {
+			continue
+		}
+		out[strings.ToLower(weekday.String())] = formatDayRange(dr)
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The same representation is valid
+// YAML, so callers using a YAML library that round-trips through JSON tags
+// (e.g. sigs.k8s.io/yaml) get (un)marshalling for free.
+func (w *Weekly) UnmarshalJSON(data []byte) error {
+	var in weeklyYAML
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	parsed, err := NewWeekly(w.location, in)
+	if err != nil {
+		return err
+	}
+	w.location = parsed.location
+	w.days = parsed.days
+	return nil
+}
+
+func formatDayRange(dr dayRange) string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", dr.startMinutes/60, dr.startMinutes%60, dr.endMinutes/60, dr.endMinutes%60)
+}
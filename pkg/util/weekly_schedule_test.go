@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeeklyContains(t *testing.T) {
+	loc := mustLoadLocation(t, "UTC")
+
+	tests := []struct {
+		name     string
+		ranges   map[string]string
+		at       time.Time
+		expected bool
+	}{
+		{
+			name:     "inside a same-day window",
+			ranges:   map[string]string{"monday": "09:00-17:00"},
+			at:       time.Date(2023, 5, 8, 12, 0, 0, 0, loc), // a Monday
+			expected: true,
+		},
+		{
+			name:     "outside a same-day window",
+			ranges:   map[string]string{"monday": "09:00-17:00"},
+			at:       time.Date(2023, 5, 8, 18, 0, 0, 0, loc),
+			expected: false,
+		},
+		{
+			name:     "closed day",
+			ranges:   map[string]string{"monday": "09:00-17:00", "saturday": "-"},
+			at:       time.Date(2023, 5, 13, 12, 0, 0, 0, loc), // a Saturday
+			expected: false,
+		},
+		{
+			name:     "wrap-around window, late half on the start day",
+			ranges:   map[string]string{"saturday": "22:00-02:00"},
+			at:       time.Date(2023, 5, 13, 23, 0, 0, 0, loc), // Saturday 23:00
+			expected: true,
+		},
+		{
+			name:     "wrap-around window, early half spills into the next day",
+			ranges:   map[string]string{"saturday": "22:00-02:00"},
+			at:       time.Date(2023, 5, 14, 1, 0, 0, 0, loc), // Sunday 01:00
+			expected: true,
+		},
+		{
+			name:     "wrap-around window does not match outside either half",
+			ranges:   map[string]string{"saturday": "22:00-02:00"},
+			at:       time.Date(2023, 5, 14, 3, 0, 0, 0, loc), // Sunday 03:00
+			expected: false,
+		},
+		{
+			name:     "wrap-around window does not leak into the day before it starts",
+			ranges:   map[string]string{"saturday": "22:00-02:00"},
+			at:       time.Date(2023, 5, 13, 21, 0, 0, 0, loc), // Saturday 21:00
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weekly, err := NewWeekly(loc, tt.ranges)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, weekly.Contains(tt.at))
+		})
+	}
+}